@@ -0,0 +1,76 @@
+package escalation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Recipient is a single escalation target: a name and phone recorded with
+// each attempt, and the backend the attempt goes through. The backend
+// delivers to its own configured destination (e.g. TWILIO_TO_NUMBER);
+// Phone is not passed to it.
+type Recipient struct {
+	Name   string `yaml:"name" json:"name"`
+	Phone  string `yaml:"phone" json:"phone"`
+	Method string `yaml:"method" json:"method"` // matches a notifier.Notifier's Name(), e.g. "twilio_sms"
+}
+
+// Stage is one rung of the escalation ladder. After is a duration string
+// (e.g. "15m") measured from the contact's created_on.
+type Stage struct {
+	After      string      `yaml:"after" json:"after"`
+	Recipients []Recipient `yaml:"recipients" json:"recipients"`
+}
+
+// Duration parses the stage's After field.
+func (s Stage) Duration() (time.Duration, error) {
+	return time.ParseDuration(s.After)
+}
+
+// OnCaller is one week's on-call recipient in the rotation.
+type OnCaller struct {
+	Name  string `yaml:"name" json:"name"`
+	Phone string `yaml:"phone" json:"phone"`
+}
+
+// Config is the escalation policy: an ordered ladder of stages, where the
+// last stage adds whoever is on-call that week from Rotation as a
+// twilio_sms Recipient.
+type Config struct {
+	Stages   []Stage    `yaml:"stages" json:"stages"`
+	Rotation []OnCaller `yaml:"rotation" json:"rotation"`
+}
+
+// LoadConfig reads the escalation policy from a YAML or JSON file, format
+// chosen by file extension (.json vs anything else is treated as YAML).
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read escalation config %s: %v", path, err)
+	}
+	var cfg Config
+	if filepath.Ext(path) == ".json" {
+		err = json.Unmarshal(b, &cfg)
+	} else {
+		err = yaml.Unmarshal(b, &cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse escalation config %s: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+// OnCall resolves the on-call recipient for the week of at, rotating
+// through Rotation by ISO week number so it stays stable across restarts.
+func (c *Config) OnCall(at time.Time) (OnCaller, bool) {
+	if len(c.Rotation) == 0 {
+		return OnCaller{}, false
+	}
+	_, week := at.ISOWeek()
+	return c.Rotation[week%len(c.Rotation)], true
+}
@@ -0,0 +1,147 @@
+// Package escalation walks unacknowledged contacts up an on-call ladder,
+// independent of the periodic contact poll in main.
+package escalation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/josedh/sgs-contact-notifier/metrics"
+	"github.com/josedh/sgs-contact-notifier/notifier"
+)
+
+// Escalator sweeps unacknowledged contacts and notifies the next stage of
+// the ladder once a contact has waited past that stage's threshold.
+type Escalator struct {
+	dbx       *sqlx.DB
+	cfg       *Config
+	notifiers map[string]notifier.Notifier
+}
+
+// NewEscalator builds an Escalator. notifiers maps a Recipient.Method
+// (e.g. "twilio_sms") to the backend an attempt for that recipient goes
+// through; the backend sends to its own configured destination.
+func NewEscalator(dbx *sqlx.DB, cfg *Config, notifiers map[string]notifier.Notifier) *Escalator {
+	return &Escalator{dbx: dbx, cfg: cfg, notifiers: notifiers}
+}
+
+// Run sweeps for due escalations every interval until ctx is cancelled.
+func (e *Escalator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.sweep(ctx); err != nil {
+				log.Errorf("Escalation sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+func (e *Escalator) sweep(ctx context.Context) error {
+	var contacts []notifier.Contact
+	q := `SELECT
+				id, name, email, phone, message, captcha_score, acknowledged, created_on, updated_on
+			FROM
+				contacts
+			WHERE
+				acknowledged = false`
+	if err := e.dbx.SelectContext(ctx, &contacts, q); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, c := range contacts {
+		stage, idx := e.dueStage(c, now)
+		if stage == nil {
+			continue
+		}
+		e.notifyStage(ctx, c, *stage, idx)
+	}
+	return nil
+}
+
+// dueStage returns the furthest stage whose threshold has elapsed, along
+// with its index, or nil and -1 if no stage has elapsed yet or that stage
+// has already been attempted for this contact.
+func (e *Escalator) dueStage(c notifier.Contact, now time.Time) (*Stage, int) {
+	elapsed := now.Sub(c.CreatedOn)
+	idx := -1
+	for i := range e.cfg.Stages {
+		d, err := e.cfg.Stages[i].Duration()
+		if err != nil {
+			log.Errorf("Invalid escalation stage duration %q: %v", e.cfg.Stages[i].After, err)
+			continue
+		}
+		if elapsed >= d {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return nil, -1
+	}
+	attempted, err := e.attempted(c.ID, idx)
+	if err != nil {
+		log.Errorf("Failed to check escalation attempts for contact %s: %v", c.ID, err)
+		return nil, -1
+	}
+	if attempted {
+		return nil, -1
+	}
+	return &e.cfg.Stages[idx], idx
+}
+
+func (e *Escalator) attempted(contactID string, stage int) (bool, error) {
+	var count int
+	q := `SELECT count(*) FROM notification_attempts WHERE contact_id=$1 AND stage=$2`
+	if err := e.dbx.Get(&count, q, contactID, stage); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (e *Escalator) notifyStage(ctx context.Context, c notifier.Contact, stage Stage, idx int) {
+	metrics.EscalationStageReached.WithLabelValues(strconv.Itoa(idx)).Inc()
+	recipients := stage.Recipients
+	if idx == len(e.cfg.Stages)-1 {
+		if onCall, ok := e.cfg.OnCall(time.Now()); ok {
+			recipients = append(recipients, Recipient{Name: onCall.Name, Phone: onCall.Phone, Method: "twilio_sms"})
+		}
+	}
+	for _, r := range recipients {
+		n, ok := e.notifiers[r.Method]
+		if !ok {
+			log.Errorf("No notifier backend configured for escalation method %q", r.Method)
+			e.recordAttempt(c.ID, idx, r, fmt.Errorf("no notifier backend configured for method %q", r.Method))
+			continue
+		}
+		err := n.Notify(ctx, c)
+		if err != nil {
+			log.Errorf("Escalation stage %d failed to notify %s for contact %s: %v", idx, r.Name, c.ID, err)
+		} else {
+			log.Infof("Escalation stage %d notified %s for contact %s", idx, r.Name, c.ID)
+		}
+		e.recordAttempt(c.ID, idx, r, err)
+	}
+}
+
+func (e *Escalator) recordAttempt(contactID string, stage int, r Recipient, notifyErr error) {
+	q := `INSERT INTO notification_attempts
+				(contact_id, stage, recipient_name, recipient_phone, method, succeeded, error, created_on)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, now())`
+	succeeded := notifyErr == nil
+	var errMsg string
+	if notifyErr != nil {
+		errMsg = notifyErr.Error()
+	}
+	if _, err := e.dbx.Exec(q, contactID, stage, r.Name, r.Phone, r.Method, succeeded, errMsg); err != nil {
+		log.Errorf("Failed to record notification attempt for contact %s: %v", contactID, err)
+	}
+}
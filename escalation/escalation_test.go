@@ -0,0 +1,59 @@
+package escalation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josedh/sgs-contact-notifier/notifier"
+)
+
+func TestConfigOnCall(t *testing.T) {
+	cfg := &Config{Rotation: []OnCaller{
+		{Name: "alice", Phone: "+15550000001"},
+		{Name: "bob", Phone: "+15550000002"},
+	}}
+
+	at := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC) // ISO week 30
+	_, week := at.ISOWeek()
+	want := cfg.Rotation[week%len(cfg.Rotation)]
+
+	got, ok := cfg.OnCall(at)
+	if !ok {
+		t.Fatal("OnCall returned ok=false with a non-empty rotation")
+	}
+	if got != want {
+		t.Errorf("OnCall(%v) = %+v, want %+v", at, got, want)
+	}
+}
+
+func TestConfigOnCallEmptyRotation(t *testing.T) {
+	cfg := &Config{}
+	if _, ok := cfg.OnCall(time.Now()); ok {
+		t.Error("OnCall with empty rotation should return ok=false")
+	}
+}
+
+func TestDueStageNotYetDue(t *testing.T) {
+	e := &Escalator{cfg: &Config{Stages: []Stage{
+		{After: "15m"},
+		{After: "30m"},
+	}}}
+	c := notifier.Contact{ID: "c1", CreatedOn: time.Now().Add(-5 * time.Minute)}
+
+	stage, idx := e.dueStage(c, time.Now())
+	if stage != nil || idx != -1 {
+		t.Errorf("dueStage = %+v, %d; want nil, -1 before the first threshold elapses", stage, idx)
+	}
+}
+
+func TestDueStageInvalidDuration(t *testing.T) {
+	e := &Escalator{cfg: &Config{Stages: []Stage{
+		{After: "not-a-duration"},
+	}}}
+	c := notifier.Contact{ID: "c1", CreatedOn: time.Now().Add(-time.Hour)}
+
+	stage, idx := e.dueStage(c, time.Now())
+	if stage != nil || idx != -1 {
+		t.Errorf("dueStage = %+v, %d; want nil, -1 when every stage duration fails to parse", stage, idx)
+	}
+}
@@ -0,0 +1,168 @@
+// Package ingest replaces the old 3-hour poll with Postgres LISTEN/NOTIFY
+// push delivery, falling back to a periodic reconciliation sweep for
+// anything the notify channel misses and an after-hours delay queue so
+// contacts outside the work window are delivered at 9am instead of dropped.
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/josedh/sgs-contact-notifier/metrics"
+)
+
+// WorkHours reports whether t falls within the sgs.com notification
+// window: 9am-3pm America/New_York, Monday through Friday.
+func WorkHours(t time.Time) (bool, error) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return false, err
+	}
+	lt := t.In(loc)
+	start := time.Date(lt.Year(), lt.Month(), lt.Day(), 9, 0, 0, 0, loc)
+	end := time.Date(lt.Year(), lt.Month(), lt.Day(), 15, 0, 0, 0, loc)
+	if lt.Before(start) || lt.After(end) || lt.Weekday() == time.Saturday || lt.Weekday() == time.Sunday {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Handler processes one contact that just arrived or came back up on a
+// reconciliation sweep.
+type Handler func(ctx context.Context, contactID string) error
+
+// Listener subscribes to postgres' new_contact channel (see migration
+// 0003) and hands contact IDs to Handler, reconciling and flushing delayed
+// contacts on its own tickers.
+type Listener struct {
+	dbx       *sqlx.DB
+	handle    Handler
+	reconcile time.Duration
+	delayed   map[string]struct{}
+}
+
+// NewListener builds a Listener. reconcile is how often to re-scan for any
+// unacknowledged contact the notify channel might have missed.
+func NewListener(dbx *sqlx.DB, handle Handler, reconcile time.Duration) *Listener {
+	return &Listener{dbx: dbx, handle: handle, reconcile: reconcile, delayed: make(map[string]struct{})}
+}
+
+// Run connects to dsn's new_contact channel and processes notifications,
+// reconciliation sweeps, and the after-hours flush until ctx is cancelled.
+func (l *Listener) Run(ctx context.Context, dsn string) error {
+	listener := pq.NewListener(dsn, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Errorf("Postgres listener event error: %v", err)
+		}
+	})
+	if err := listener.Listen("new_contact"); err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	reconcileTicker := time.NewTicker(l.reconcile)
+	defer reconcileTicker.Stop()
+	flushTicker := time.NewTicker(time.Minute)
+	defer flushTicker.Stop()
+	pingTicker := time.NewTicker(90 * time.Second)
+	defer pingTicker.Stop()
+
+	l.sweep(ctx) // catch anything inserted before we started listening
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-listener.Notify:
+			if n == nil {
+				// listener reconnected; the next reconcile sweep will catch up
+				continue
+			}
+			metrics.ContactsObserved.Inc()
+			l.onArrive(ctx, n.Extra)
+		case <-reconcileTicker.C:
+			l.sweep(ctx)
+		case <-flushTicker.C:
+			l.flushIfWorkHours(ctx)
+		case <-pingTicker.C:
+			go listener.Ping()
+		}
+	}
+}
+
+// onArrive handles a single contact ID, queueing it for the 9am flush if
+// it's currently outside work hours instead of notifying immediately.
+func (l *Listener) onArrive(ctx context.Context, contactID string) {
+	ok, err := WorkHours(time.Now())
+	if err != nil {
+		log.Errorf("Failed to resolve work hours, queueing contact %s: %v", contactID, err)
+		l.delayed[contactID] = struct{}{}
+		return
+	}
+	if !ok {
+		log.Infof("Contact %s arrived outside work hours, queueing for 9am", contactID)
+		l.delayed[contactID] = struct{}{}
+		return
+	}
+	if err := l.handle(ctx, contactID); err != nil {
+		log.Errorf("Failed to notify contact %s: %v", contactID, err)
+	}
+}
+
+func (l *Listener) flushIfWorkHours(ctx context.Context) {
+	if len(l.delayed) == 0 {
+		return
+	}
+	ok, err := WorkHours(time.Now())
+	if err != nil || !ok {
+		return
+	}
+	log.Infof("Flushing %d after-hours contact(s) now that it's within work hours", len(l.delayed))
+	pending := l.delayed
+	l.delayed = make(map[string]struct{})
+	for id := range pending {
+		if err := l.handle(ctx, id); err != nil {
+			log.Errorf("Failed to notify contact %s: %v", id, err)
+		}
+	}
+}
+
+// sweep is the reconciliation safety net: it re-checks every contact that
+// hasn't been delivered yet in case the LISTEN/NOTIFY connection missed an
+// insert, e.g. during a reconnect. This is about delivery, not human
+// acknowledgement, so it gates on notified_on rather than acknowledged
+// (escalation owns the acknowledged=false ladder).
+func (l *Listener) sweep(ctx context.Context) {
+	var rows []struct {
+		ID        string    `db:"id"`
+		CreatedOn time.Time `db:"created_on"`
+	}
+	q := `SELECT id, created_on FROM contacts WHERE notified_on IS NULL ORDER BY created_on ASC`
+	err := metrics.ObserveQuery("undelivered_contacts", func() error {
+		return l.dbx.SelectContext(ctx, &rows, q)
+	})
+	if err != nil {
+		log.Errorf("Reconciliation sweep failed to query contacts: %v", err)
+		return
+	}
+
+	metrics.ContactsObserved.Add(float64(len(rows)))
+	var oldest sql.NullTime
+	if err := l.dbx.GetContext(ctx, &oldest, `SELECT min(created_on) FROM contacts WHERE acknowledged = false`); err != nil {
+		log.Errorf("Reconciliation sweep failed to query oldest unacknowledged contact: %v", err)
+	} else if oldest.Valid {
+		metrics.OldestUnacknowledgedAge.Set(time.Since(oldest.Time).Seconds())
+	} else {
+		metrics.OldestUnacknowledgedAge.Set(0)
+	}
+	metrics.LastSuccessfulPoll.SetToCurrentTime()
+
+	for _, r := range rows {
+		l.onArrive(ctx, r.ID)
+	}
+}
@@ -0,0 +1,38 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkHours(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load America/New_York: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"weekday mid-morning", time.Date(2026, 7, 20, 10, 0, 0, 0, loc), true}, // Monday
+		{"weekday at opening", time.Date(2026, 7, 20, 9, 0, 0, 0, loc), true},
+		{"weekday at closing", time.Date(2026, 7, 20, 15, 0, 0, 0, loc), true},
+		{"weekday before opening", time.Date(2026, 7, 20, 8, 59, 0, 0, loc), false},
+		{"weekday after closing", time.Date(2026, 7, 20, 15, 0, 1, 0, loc), false},
+		{"saturday during the day", time.Date(2026, 7, 25, 10, 0, 0, 0, loc), false},
+		{"sunday during the day", time.Date(2026, 7, 26, 10, 0, 0, 0, loc), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkHours(tt.t)
+			if err != nil {
+				t.Fatalf("WorkHours(%v) returned error: %v", tt.t, err)
+			}
+			if got != tt.want {
+				t.Errorf("WorkHours(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
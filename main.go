@@ -2,38 +2,24 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
 	"fmt"
-	"net/http"
-	"net/url"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
-)
 
-// Contact defines as contact as saved in the postgres table for sgs.com
-type contact struct {
-	ID           string    `db:"id"`
-	Name         string    `db:"name"`
-	Email        string    `db:"email"`
-	Phone        string    `db:"phone"`
-	Message      string    `db:"message"`
-	CaptchaScore string    `db:"captcha_score"`
-	Acknowledged bool      `db:"acknowledged"`
-	CreatedOn    time.Time `db:"created_on"`
-	UpdatedOn    time.Time `db:"updated_on"`
-}
+	"github.com/josedh/sgs-contact-notifier/escalation"
+	"github.com/josedh/sgs-contact-notifier/ingest"
+	"github.com/josedh/sgs-contact-notifier/metrics"
+	"github.com/josedh/sgs-contact-notifier/notifier"
+	"github.com/josedh/sgs-contact-notifier/webhook"
+)
 
 var isDev bool
 
-func (c contact) String() string {
-	return fmt.Sprintf("Contact name: %s, email: %s, phone: %s", c.Name, c.Email, c.Phone)
-}
-
 func init() {
 	if _, exists := os.LookupEnv("DEV"); exists {
 		// this is the dev environment, write to console and set var
@@ -65,101 +51,101 @@ func main() {
 		log.Fatalf("Failed to set up postgres conn: %v", err)
 	}
 
-	// new contact loop
-	tickChan := time.Tick(3 * time.Hour)
-	for {
-		select {
-		case <-tickChan:
-			loc, err := time.LoadLocation("America/New_York")
-			if err != nil {
-				log.Errorf("Failed to load EST location for date data, please contact webmaster")
-			}
-			currTime := time.Now()
-			start := time.Date(currTime.Year(), currTime.Month(), currTime.Day(), 9, 00, 00, 00, loc)
-			end := time.Date(currTime.Year(), currTime.Month(), currTime.Day(), 15, 00, 00, 00, loc)
-			if currTime.Before(start) ||
-				currTime.After(end) ||
-				currTime.Weekday() == time.Saturday ||
-				currTime.Weekday() == time.Sunday {
-				// if outside work hours, don't message
-				log.Info("Outside of work hours, skipping notifications")
-				continue
-			}
-			log.Infof("Checking sgs.com contacts table at: %v", time.Now().String())
-			if err := checkContacts(dbx); err != nil {
-				// there was an error checking for new contacts, log and report
-				log.Errorf("Failed to check postgres for new contacts on sgs.com: %v", err)
-			}
+	// Prometheus metrics + health check, so a silently failing notifier
+	// shows up on a dashboard instead of being discovered by an angry customer
+	go func() {
+		addr := os.Getenv("METRICS_ADDR")
+		if addr == "" {
+			addr = ":9090"
+		}
+		srv := metrics.NewServer(dbx, os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN"))
+		if err := srv.ListenAndServe(addr); err != nil {
+			log.Fatalf("Metrics server stopped: %v", err)
+		}
+	}()
+
+	// Twilio calls back here to acknowledge contacts and report delivery status
+	webhookSrv := webhook.NewServer(dbx, os.Getenv("TWILIO_AUTH_TOKEN"), os.Getenv("TWILIO_WEBHOOK_BASE_URL"))
+	go func() {
+		addr := os.Getenv("WEBHOOK_ADDR")
+		if addr == "" {
+			addr = ":8080"
+		}
+		if err := webhookSrv.ListenAndServe(addr); err != nil {
+			log.Fatalf("Twilio webhook server stopped: %v", err)
 		}
+	}()
+
+	statusCallbackURL := webhookSrv.StatusCallbackURL()
+	notifiers := notifier.FromEnv(os.Getenv("NOTIFIERS"), statusCallbackURL)
+	if len(notifiers) == 0 {
+		log.Warn("NOTIFIERS is empty, defaulting to twilio_sms")
+		notifiers = notifier.FromEnv("twilio_sms", statusCallbackURL)
+	}
+	byMethod := make(map[string]notifier.Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byMethod[n.Name()] = n
+	}
+
+	// Escalates contacts still unacknowledged past each stage's threshold,
+	// independent of the new_contact listener below.
+	if escCfgPath := os.Getenv("ESCALATION_CONFIG"); escCfgPath != "" {
+		escCfg, err := escalation.LoadConfig(escCfgPath)
+		if err != nil {
+			log.Errorf("Failed to load escalation config: %v", err)
+		} else {
+			escalator := escalation.NewEscalator(dbx, escCfg, byMethod)
+			go escalator.Run(context.Background(), time.Minute)
+		}
+	}
+
+	// Pushed new_contact notifications drive delivery now (see migration
+	// 0003); a 5-minute reconciliation sweep is the safety net for
+	// anything the LISTEN/NOTIFY connection misses.
+	listener := ingest.NewListener(dbx, func(ctx context.Context, contactID string) error {
+		return notifyContact(ctx, dbx, notifiers, contactID)
+	}, 5*time.Minute)
+	if err := listener.Run(context.Background(), os.Getenv("DATABASE_URL")); err != nil {
+		log.Fatalf("Postgres notify listener stopped: %v", err)
 	}
 }
 
-func checkContacts(dbx *sqlx.DB) error {
-	var res []contact
+// notifyContactTimeout bounds a single notify attempt so one wedged
+// notifier backend can't hang the Listener's single-goroutine event loop
+// forever.
+const notifyContactTimeout = 30 * time.Second
+
+// notifyContact loads a single contact by ID and fans it out to notifiers.
+// It only records that the contact was *delivered* (notified_on); whether
+// it's *acknowledged* is reserved for the on-call person replying with the
+// contact ID (see webhook.handleInbound), so escalation keeps working.
+func notifyContact(ctx context.Context, dbx *sqlx.DB, notifiers []notifier.Notifier, contactID string) error {
+	ctx, cancel := context.WithTimeout(ctx, notifyContactTimeout)
+	defer cancel()
+
+	var c notifier.Contact
 	var q = `SELECT
 				id, name, email, phone, message, captcha_score, acknowledged, created_on, updated_on
 			FROM
 				contacts
 			WHERE
-				acknowledged = false`
-	if err := dbx.Select(&res, q); err != nil {
-		log.Debug(err)
+				id = $1 AND notified_on IS NULL`
+	err := metrics.ObserveQuery("load_contact", func() error {
+		return dbx.GetContext(ctx, &c, q, contactID)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// already notified between being queued and now
+			return nil
+		}
 		return err
 	}
-	twilioSID := os.Getenv("TWILIO_ACCOUNT_SID")
-	twilioAuth := os.Getenv("TWILIO_AUTH_TOKEN")
-	if twilioSID == "" || twilioAuth == "" {
-		return fmt.Errorf("Invalid twilio credentials, please check those on the server env and try again")
+	log.Infof("Contact %s has not been notified yet, notifying...", c.Name)
+	if err := notifier.FanOut(ctx, notifiers, c); err != nil {
+		return fmt.Errorf("failed to send contact %v to POC: %v", c.String(), err)
 	}
-	for _, r := range res {
-		log.Infof("Contact %s is unacknowledged, notifying...", r.Name)
-		if err := sendToPOC(r, twilioSID, twilioAuth); err != nil {
-			// An error occurred sending contact info to sgs admins. Log it
-			log.Errorf("Failed to send contact %v to POC: %v", r.String(), err)
-		}
-		time.Sleep(15 * time.Second) // Give it some time before sending next contact
+	if _, err := dbx.ExecContext(ctx, `UPDATE contacts SET notified_on=now(), updated_on=now() WHERE id=$1`, c.ID); err != nil {
+		return fmt.Errorf("failed to mark contact %s as notified: %v", c.ID, err)
 	}
-	log.Infof("Done sending contacts to sgs owner, returning to idle loop")
 	return nil
 }
-
-func sendToPOC(c contact, sid, auth string) error {
-	var (
-		urlStr = "https://api.twilio.com/2010-04-01/Accounts/" + sid + "/Messages.json"
-		client = &http.Client{}
-		err    error
-	)
-	// Format the message to send to sgs admins
-	msg := formatMessage(c)
-
-	// Set up the request
-	req, _ := http.NewRequest("POST", urlStr, &msg)
-	req.SetBasicAuth(sid, auth)
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	// Send it!
-	resp, _ := client.Do(req)
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		var data map[string]interface{}
-		decoder := json.NewDecoder(resp.Body)
-		if e := decoder.Decode(&data); e != nil {
-			log.Debugf("Failed to parse response after sending message: %v", e)
-		}
-		log.Debugf("Response from sent message: %v", data)
-	} else {
-		err = fmt.Errorf("Failed to send message to contact. Issue: %v", resp.Status)
-	}
-	return err
-}
-
-func formatMessage(c contact) strings.Reader {
-	var msgToPOC = "We are being contacted by '%s' with email: '%s' and phone number '%s'" +
-		"for the following reason: '%s'.\n" +
-		"Please acknowledged receipt of this contact by replying '%s' to this message."
-	msgData := url.Values{}
-	msgData.Set("From", os.Getenv("TWILIO_FROM_NUMBER"))
-	msgData.Set("To", os.Getenv("TWILIO_TO_NUMBER"))
-	msgData.Set("provideFeedback", "true")
-	msgData.Set("Body", fmt.Sprintf(msgToPOC, c.Name, c.Email, c.Phone, c.Message, c.ID))
-	return *strings.NewReader(msgData.Encode())
-}
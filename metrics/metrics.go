@@ -0,0 +1,77 @@
+// Package metrics holds the Prometheus instruments shared across the
+// notifier, escalation and ingest packages, plus the /metrics and /healthz
+// HTTP server that exposes them.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// ContactsObserved counts every contact the notifier has seen, via a
+	// push notification or a reconciliation sweep. A contact that is still
+	// undelivered is counted again on each sweep that sees it.
+	ContactsObserved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sgs_contacts_observed_total",
+		Help: "Total number of contacts seen by the notifier.",
+	})
+
+	// NotificationsSent counts notify attempts per backend and outcome
+	// ("success"/"failure").
+	NotificationsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sgs_notifications_sent_total",
+		Help: "Total notifications attempted, labeled by backend and outcome.",
+	}, []string{"backend", "outcome"})
+
+	// TwilioRequestDuration tracks Twilio API request latency per endpoint.
+	TwilioRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sgs_twilio_request_duration_seconds",
+		Help:    "Latency of Twilio API requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	// TwilioStatusCodes counts Twilio API responses per endpoint and status.
+	TwilioStatusCodes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sgs_twilio_http_status_total",
+		Help: "Twilio API HTTP status codes, labeled by endpoint and status.",
+	}, []string{"endpoint", "status"})
+
+	// EscalationStageReached counts escalation attempts per stage.
+	EscalationStageReached = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sgs_escalation_stage_reached_total",
+		Help: "Escalation attempts, labeled by stage index.",
+	}, []string{"stage"})
+
+	// DBQueryDuration tracks postgres query latency per named query.
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sgs_db_query_duration_seconds",
+		Help:    "Duration of postgres queries, labeled by query name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+
+	// OldestUnacknowledgedAge is the age in seconds of the oldest
+	// unacknowledged contact, 0 if none are pending.
+	OldestUnacknowledgedAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sgs_oldest_unacknowledged_contact_age_seconds",
+		Help: "Age in seconds of the oldest unacknowledged contact, 0 if none are pending.",
+	})
+
+	// LastSuccessfulPoll is the unix timestamp of the last successful
+	// reconciliation sweep.
+	LastSuccessfulPoll = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sgs_last_successful_poll_timestamp_seconds",
+		Help: "Unix timestamp of the last successful contact reconciliation sweep.",
+	})
+)
+
+// ObserveQuery runs fn, recording its duration under the named query in
+// DBQueryDuration regardless of whether it errors.
+func ObserveQuery(name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	DBQueryDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	return err
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server exposes /metrics (Prometheus) and /healthz.
+type Server struct {
+	dbx         *sqlx.DB
+	twilioSID   string
+	twilioAuth  string
+	checkTwilio bool
+}
+
+// NewServer builds a Server. When twilioSID/twilioAuth are set, /healthz
+// also does a Twilio account fetch to confirm the credentials still work.
+func NewServer(dbx *sqlx.DB, twilioSID, twilioAuth string) *Server {
+	return &Server{
+		dbx:         dbx,
+		twilioSID:   twilioSID,
+		twilioAuth:  twilioAuth,
+		checkTwilio: twilioSID != "" && twilioAuth != "",
+	}
+}
+
+// Handler returns the mux serving /metrics and /healthz.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	return mux
+}
+
+// ListenAndServe starts the metrics/health server on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	log.Infof("Starting metrics server on %s", addr)
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+type healthStatus struct {
+	DB     string `json:"db"`
+	Twilio string `json:"twilio,omitempty"`
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	status := healthStatus{DB: "ok"}
+	healthy := true
+
+	if err := s.dbx.PingContext(ctx); err != nil {
+		status.DB = err.Error()
+		healthy = false
+	}
+
+	if s.checkTwilio {
+		if err := s.pingTwilio(ctx); err != nil {
+			status.Twilio = err.Error()
+			healthy = false
+		} else {
+			status.Twilio = "ok"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Debugf("Failed to write healthz response: %v", err)
+	}
+}
+
+func (s *Server) pingTwilio(ctx context.Context) error {
+	urlStr := "https://api.twilio.com/2010-04-01/Accounts/" + s.twilioSID + ".json"
+	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.twilioSID, s.twilioAuth)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio account fetch returned %s", resp.Status)
+	}
+	return nil
+}
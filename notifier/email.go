@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+)
+
+// Email sends the contact to the on-call inbox over SMTP.
+type Email struct {
+	host, port, user, pass, from, to string
+}
+
+// NewEmail builds an Email notifier from SMTP_HOST, SMTP_PORT, SMTP_USER,
+// SMTP_PASS, SMTP_FROM and SMTP_TO.
+func NewEmail() *Email {
+	return &Email{
+		host: os.Getenv("SMTP_HOST"),
+		port: os.Getenv("SMTP_PORT"),
+		user: os.Getenv("SMTP_USER"),
+		pass: os.Getenv("SMTP_PASS"),
+		from: os.Getenv("SMTP_FROM"),
+		to:   os.Getenv("SMTP_TO"),
+	}
+}
+
+// Name implements Notifier.
+func (e *Email) Name() string { return "email" }
+
+// Notify implements Notifier.
+func (e *Email) Notify(ctx context.Context, c Contact) error {
+	if e.host == "" || e.to == "" {
+		return fmt.Errorf("invalid SMTP config, please check SMTP_HOST/SMTP_TO and try again")
+	}
+	subject := fmt.Sprintf("New sgs.com contact: %s", c.Name)
+	body := fmt.Sprintf(
+		"We are being contacted by '%s' with email '%s' and phone number '%s' "+
+			"for the following reason: '%s'.\nContact ID: %s",
+		c.Name, c.Email, c.Phone, c.Message, c.ID,
+	)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", e.from, e.to, subject, body)
+
+	addr := e.host + ":" + e.port
+	var auth smtp.Auth
+	if e.user != "" {
+		auth = smtp.PlainAuth("", e.user, e.pass, e.host)
+	}
+	if err := smtp.SendMail(addr, auth, e.from, []string{e.to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email to contact: %v", err)
+	}
+	return nil
+}
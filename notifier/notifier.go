@@ -0,0 +1,153 @@
+// Package notifier fans a contact out to one or more configured delivery
+// backends (SMS, voice call, email, ntfy, Slack) instead of assuming Twilio
+// SMS is the only way to reach the sgs.com admins.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/josedh/sgs-contact-notifier/metrics"
+)
+
+// Contact is a contact submitted through sgs.com that still needs a human to
+// see it.
+type Contact struct {
+	ID           string    `db:"id"`
+	Name         string    `db:"name"`
+	Email        string    `db:"email"`
+	Phone        string    `db:"phone"`
+	Message      string    `db:"message"`
+	CaptchaScore string    `db:"captcha_score"`
+	Acknowledged bool      `db:"acknowledged"`
+	CreatedOn    time.Time `db:"created_on"`
+	UpdatedOn    time.Time `db:"updated_on"`
+}
+
+func (c Contact) String() string {
+	return fmt.Sprintf("Contact name: %s, email: %s, phone: %s", c.Name, c.Email, c.Phone)
+}
+
+// Notifier delivers a contact to a human through some backend.
+type Notifier interface {
+	// Name identifies the backend for logging and the NOTIFIERS env var.
+	Name() string
+	// Notify delivers the contact, returning an error if the backend could
+	// not confirm delivery.
+	Notify(ctx context.Context, c Contact) error
+}
+
+// FromEnv builds the list of Notifiers named in the comma-separated
+// NOTIFIERS env var (e.g. "twilio_sms,voice,email"). Unknown names are
+// skipped with a warning so a typo doesn't take the whole fan-out down.
+// statusCallbackURL, if non-empty, is where TwilioSMS points Twilio's
+// delivery status callbacks (see webhook.Server.StatusCallbackURL).
+func FromEnv(notifiers, statusCallbackURL string) []Notifier {
+	var out []Notifier
+	for _, name := range strings.Split(notifiers, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		n, err := build(name, statusCallbackURL)
+		if err != nil {
+			log.Warnf("Skipping notifier %q: %v", name, err)
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func build(name, statusCallbackURL string) (Notifier, error) {
+	switch name {
+	case "twilio_sms":
+		return NewTwilioSMS(statusCallbackURL), nil
+	case "voice":
+		return NewTwilioVoice(), nil
+	case "email":
+		return NewEmail(), nil
+	case "ntfy":
+		return NewNtfy(), nil
+	case "slack":
+		return NewSlack(), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier backend %q", name)
+	}
+}
+
+// result is sent back from a single backend's notify attempt.
+type result struct {
+	name string
+	err  error
+}
+
+// FanOut runs every notifier for c in parallel, retrying each with backoff,
+// and returns nil as soon as at least one backend succeeds. It only returns
+// an error once every backend has been exhausted.
+func FanOut(ctx context.Context, notifiers []Notifier, c Contact) error {
+	if len(notifiers) == 0 {
+		return fmt.Errorf("no notifier backends configured, set NOTIFIERS")
+	}
+	results := make(chan result, len(notifiers))
+	for _, n := range notifiers {
+		go func(n Notifier) {
+			err := notifyWithRetry(ctx, n, c, 3, time.Second)
+			results <- result{name: n.Name(), err: err}
+		}(n)
+	}
+
+	record := func(r result) {
+		if r.err != nil {
+			metrics.NotificationsSent.WithLabelValues(r.name, "failure").Inc()
+			log.Errorf("Notifier %s failed to deliver contact %s: %v", r.name, c.ID, r.err)
+			return
+		}
+		metrics.NotificationsSent.WithLabelValues(r.name, "success").Inc()
+		log.Infof("Notifier %s delivered contact %s", r.name, c.ID)
+	}
+
+	var errs []string
+	for i := 0; i < len(notifiers); i++ {
+		r := <-results
+		record(r)
+		if r.err == nil {
+			// Keep draining the rest in the background so every backend's
+			// outcome still gets recorded, without making the caller wait
+			// on stragglers once one backend has already succeeded.
+			if remaining := len(notifiers) - i - 1; remaining > 0 {
+				go func() {
+					for j := 0; j < remaining; j++ {
+						record(<-results)
+					}
+				}()
+			}
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", r.name, r.err))
+	}
+	return fmt.Errorf("all notifier backends failed: %v", errs)
+}
+
+// notifyWithRetry retries n.Notify with exponential backoff, bailing out
+// early if ctx is done.
+func notifyWithRetry(ctx context.Context, n Notifier, c Contact, attempts int, backoff time.Duration) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = n.Notify(ctx, c); err == nil {
+			return nil
+		}
+		log.Debugf("Notifier %s attempt %d/%d failed: %v", n.Name(), i+1, attempts, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
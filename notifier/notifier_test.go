@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeNotifier fails the first failures attempts, then succeeds (or always
+// fails if failures < 0).
+type fakeNotifier struct {
+	name     string
+	failures int32
+	calls    int32
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Notify(ctx context.Context, c Contact) error {
+	atomic.AddInt32(&f.calls, 1)
+	if f.failures < 0 || atomic.LoadInt32(&f.calls) <= f.failures {
+		return errors.New("simulated failure")
+	}
+	return nil
+}
+
+func TestFanOutNoNotifiers(t *testing.T) {
+	if err := FanOut(context.Background(), nil, Contact{ID: "c1"}); err == nil {
+		t.Error("FanOut with no notifiers should return an error")
+	}
+}
+
+func TestFanOutReturnsOnFirstSuccess(t *testing.T) {
+	good := &fakeNotifier{name: "good"}
+	bad := &fakeNotifier{name: "bad", failures: -1}
+
+	start := time.Now()
+	err := FanOut(context.Background(), []Notifier{good, bad}, Contact{ID: "c1"})
+	if err != nil {
+		t.Fatalf("FanOut returned %v, want nil once one backend succeeds", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("FanOut took %v, want it to return as soon as the fast backend succeeds without waiting on retries of the failing one", elapsed)
+	}
+}
+
+func TestFanOutRetriesUntilSuccess(t *testing.T) {
+	flaky := &fakeNotifier{name: "flaky", failures: 1}
+	if err := FanOut(context.Background(), []Notifier{flaky}, Contact{ID: "c1"}); err != nil {
+		t.Fatalf("FanOut returned %v, want nil after the backend succeeds on retry", err)
+	}
+	if calls := atomic.LoadInt32(&flaky.calls); calls != 2 {
+		t.Errorf("backend was called %d times, want 2 (one failure, one success)", calls)
+	}
+}
+
+func TestFanOutAllFail(t *testing.T) {
+	a := &fakeNotifier{name: "a", failures: -1}
+	b := &fakeNotifier{name: "b", failures: -1}
+	if err := FanOut(context.Background(), []Notifier{a, b}, Contact{ID: "c1"}); err == nil {
+		t.Error("FanOut should return an error once every backend is exhausted")
+	}
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Ntfy publishes the contact as a push notification via ntfy.sh (or a
+// self-hosted ntfy server).
+type Ntfy struct {
+	serverURL, topic string
+	client           *http.Client
+}
+
+// NewNtfy builds an Ntfy notifier from NTFY_URL (defaults to
+// https://ntfy.sh) and NTFY_TOPIC.
+func NewNtfy() *Ntfy {
+	serverURL := os.Getenv("NTFY_URL")
+	if serverURL == "" {
+		serverURL = "https://ntfy.sh"
+	}
+	return &Ntfy{
+		serverURL: serverURL,
+		topic:     os.Getenv("NTFY_TOPIC"),
+		client:    &http.Client{},
+	}
+}
+
+// Name implements Notifier.
+func (n *Ntfy) Name() string { return "ntfy" }
+
+// Notify implements Notifier.
+func (n *Ntfy) Notify(ctx context.Context, c Contact) error {
+	if n.topic == "" {
+		return fmt.Errorf("invalid ntfy config, please set NTFY_TOPIC and try again")
+	}
+	body := fmt.Sprintf(
+		"%s (%s, %s): %s",
+		c.Name, c.Email, c.Phone, c.Message,
+	)
+	urlStr := strings.TrimRight(n.serverURL, "/") + "/" + n.topic
+
+	req, err := http.NewRequestWithContext(ctx, "POST", urlStr, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", "New sgs.com contact")
+	req.Header.Set("Priority", "high")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish ntfy notification: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to publish ntfy notification, issue: %v", resp.Status)
+	}
+	return nil
+}
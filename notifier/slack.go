@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// Slack posts the contact to a Slack incoming webhook.
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlack builds a Slack notifier from SLACK_WEBHOOK_URL.
+func NewSlack() *Slack {
+	return &Slack{
+		webhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
+		client:     &http.Client{},
+	}
+}
+
+// Name implements Notifier.
+func (s *Slack) Name() string { return "slack" }
+
+// Notify implements Notifier.
+func (s *Slack) Notify(ctx context.Context, c Contact) error {
+	if s.webhookURL == "" {
+		return fmt.Errorf("invalid slack config, please set SLACK_WEBHOOK_URL and try again")
+	}
+	payload, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf(
+			"New sgs.com contact from *%s* <%s>, phone %s: %s",
+			c.Name, c.Email, c.Phone, c.Message,
+		),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack notification: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post slack notification, issue: %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/josedh/sgs-contact-notifier/metrics"
+)
+
+// doTwilio performs req and records its latency and status code under
+// endpoint in the shared Twilio metrics.
+func doTwilio(client *http.Client, endpoint string, req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := client.Do(req)
+	metrics.TwilioRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
+	}
+	metrics.TwilioStatusCodes.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TwilioSMS sends the contact to the on-call number via a Twilio SMS, the
+// original (and still default) notification path.
+type TwilioSMS struct {
+	sid, auth, from, to, statusCallback string
+	client                              *http.Client
+}
+
+// NewTwilioSMS builds a TwilioSMS notifier from TWILIO_ACCOUNT_SID,
+// TWILIO_AUTH_TOKEN, TWILIO_FROM_NUMBER and TWILIO_TO_NUMBER. statusCallback,
+// if non-empty, is set as the outbound message's StatusCallback so Twilio
+// reports delivery status back to it (see webhook.Server.StatusCallbackURL,
+// which is what callers should pass here).
+func NewTwilioSMS(statusCallback string) *TwilioSMS {
+	return &TwilioSMS{
+		sid:            os.Getenv("TWILIO_ACCOUNT_SID"),
+		auth:           os.Getenv("TWILIO_AUTH_TOKEN"),
+		from:           os.Getenv("TWILIO_FROM_NUMBER"),
+		to:             os.Getenv("TWILIO_TO_NUMBER"),
+		statusCallback: statusCallback,
+		client:         &http.Client{},
+	}
+}
+
+// Name implements Notifier.
+func (t *TwilioSMS) Name() string { return "twilio_sms" }
+
+// Notify implements Notifier.
+func (t *TwilioSMS) Notify(ctx context.Context, c Contact) error {
+	if t.sid == "" || t.auth == "" {
+		return fmt.Errorf("invalid twilio credentials, please check those on the server env and try again")
+	}
+	urlStr := "https://api.twilio.com/2010-04-01/Accounts/" + t.sid + "/Messages.json"
+	body := strings.NewReader(formatSMSMessage(c, t.from, t.to, t.statusCallback).Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", urlStr, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.sid, t.auth)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := doTwilio(t.client, "messages", req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS to contact: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send message to contact, issue: %v", resp.Status)
+	}
+	var data map[string]interface{}
+	if e := json.NewDecoder(resp.Body).Decode(&data); e != nil {
+		return nil
+	}
+	return nil
+}
+
+func formatSMSMessage(c Contact, from, to, statusCallback string) url.Values {
+	var msgToPOC = "We are being contacted by '%s' with email: '%s' and phone number '%s'" +
+		"for the following reason: '%s'.\n" +
+		"Please acknowledged receipt of this contact by replying '%s' to this message."
+	msgData := url.Values{}
+	msgData.Set("From", from)
+	msgData.Set("To", to)
+	msgData.Set("provideFeedback", "true")
+	msgData.Set("Body", fmt.Sprintf(msgToPOC, c.Name, c.Email, c.Phone, c.Message, c.ID))
+	if statusCallback != "" {
+		msgData.Set("StatusCallback", statusCallback)
+	}
+	return msgData
+}
@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TwilioVoice places an automated phone call reading the contact details
+// via TwiML <Say>, for when an SMS alone doesn't get noticed.
+type TwilioVoice struct {
+	sid, auth, from, to string
+	client              *http.Client
+}
+
+// NewTwilioVoice builds a TwilioVoice notifier from the same
+// TWILIO_ACCOUNT_SID/TWILIO_AUTH_TOKEN/TWILIO_FROM_NUMBER/TWILIO_TO_NUMBER
+// env vars as TwilioSMS.
+func NewTwilioVoice() *TwilioVoice {
+	return &TwilioVoice{
+		sid:    os.Getenv("TWILIO_ACCOUNT_SID"),
+		auth:   os.Getenv("TWILIO_AUTH_TOKEN"),
+		from:   os.Getenv("TWILIO_FROM_NUMBER"),
+		to:     os.Getenv("TWILIO_TO_NUMBER"),
+		client: &http.Client{},
+	}
+}
+
+// Name implements Notifier.
+func (t *TwilioVoice) Name() string { return "voice" }
+
+// Notify implements Notifier.
+func (t *TwilioVoice) Notify(ctx context.Context, c Contact) error {
+	if t.sid == "" || t.auth == "" {
+		return fmt.Errorf("invalid twilio credentials, please check those on the server env and try again")
+	}
+	urlStr := "https://api.twilio.com/2010-04-01/Accounts/" + t.sid + "/Calls.json"
+	twiml := fmt.Sprintf(
+		"<Response><Say>You have a new sgs.com contact from %s. "+
+			"Phone number %s. Message: %s</Say></Response>",
+		ttsSafe(c.Name), ttsSafe(c.Phone), ttsSafe(c.Message),
+	)
+	data := url.Values{}
+	data.Set("From", t.from)
+	data.Set("To", t.to)
+	data.Set("Twiml", twiml)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", urlStr, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.sid, t.auth)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := doTwilio(t.client, "calls", req)
+	if err != nil {
+		return fmt.Errorf("failed to place voice call to contact: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to place voice call, issue: %v", resp.Status)
+	}
+	return nil
+}
+
+// ttsSafe strips characters that read poorly or break XML in the <Say> body.
+func ttsSafe(s string) string {
+	r := strings.NewReplacer("<", "", ">", "", "&", "and")
+	return r.Replace(s)
+}
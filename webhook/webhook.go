@@ -0,0 +1,142 @@
+// Package webhook serves Twilio's inbound SMS and status callback requests
+// so contact acknowledgements and delivery state make it back into postgres.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server handles Twilio webhook callbacks and persists the results to postgres.
+type Server struct {
+	dbx       *sqlx.DB
+	authToken string
+	baseURL   string
+}
+
+// NewServer builds a Server. baseURL is the externally reachable
+// scheme+host Twilio is configured to call (no path), used together with
+// each request's path to reconstruct the exact URL Twilio signed.
+func NewServer(dbx *sqlx.DB, authToken, baseURL string) *Server {
+	return &Server{dbx: dbx, authToken: authToken, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// StatusCallbackURL is the full URL Twilio should call with delivery
+// status updates for a message sent through this server, or "" if no
+// baseURL was configured.
+func (s *Server) StatusCallbackURL() string {
+	if s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + "/twilio/status"
+}
+
+// Handler returns the mux Twilio should be pointed at.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/twilio/inbound", s.handleInbound)
+	mux.HandleFunc("/twilio/status", s.handleStatus)
+	return mux
+}
+
+// ListenAndServe starts the webhook server on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	log.Infof("Starting Twilio webhook server on %s", addr)
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+func (s *Server) handleInbound(w http.ResponseWriter, r *http.Request) {
+	if !s.validSignature(r, s.baseURL+r.URL.Path) {
+		log.Errorf("Rejected inbound Twilio webhook: invalid X-Twilio-Signature")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		log.Errorf("Failed to parse inbound Twilio webhook form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body := strings.TrimSpace(r.FormValue("Body"))
+	from := r.FormValue("From")
+	sid := r.FormValue("MessageSid")
+	log.Infof("Received inbound SMS from %s (sid=%s): %q", from, sid, body)
+
+	var q = `UPDATE contacts SET acknowledged=true, updated_on=now() WHERE id=$1 AND acknowledged=false`
+	res, err := s.dbx.Exec(q, body)
+	if err != nil {
+		log.Errorf("Failed to acknowledge contact %s: %v", body, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		log.Debugf("Inbound reply %q did not match an unacknowledged contact", body)
+	} else {
+		log.Infof("Contact %s acknowledged via SMS reply", body)
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Response></Response>`))
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !s.validSignature(r, s.baseURL+r.URL.Path) {
+		log.Errorf("Rejected Twilio status webhook: invalid X-Twilio-Signature")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		log.Errorf("Failed to parse Twilio status webhook form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	sid := r.FormValue("MessageSid")
+	status := r.FormValue("MessageStatus")
+	errorCode := r.FormValue("ErrorCode")
+	log.Infof("Twilio message %s status: %s", sid, status)
+
+	var q = `INSERT INTO message_events (message_sid, status, error_code, created_on)
+			VALUES ($1, $2, $3, now())`
+	if _, err := s.dbx.Exec(q, sid, status, errorCode); err != nil {
+		log.Errorf("Failed to record message event for %s: %v", sid, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validSignature checks the X-Twilio-Signature header per Twilio's scheme:
+// HMAC-SHA1(authToken, url + sorted "key"+"value" POST params), base64
+// compared. url must be the exact URL Twilio was configured to call for
+// this request (Twilio signs each webhook against its own URL).
+func (s *Server) validSignature(r *http.Request, url string) bool {
+	sig := r.Header.Get("X-Twilio-Signature")
+	if sig == "" || s.authToken == "" {
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		return false
+	}
+	keys := make([]string, 0, len(r.PostForm))
+	for k := range r.PostForm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(url)
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteString(r.PostForm.Get(k))
+	}
+
+	mac := hmac.New(sha1.New, []byte(s.authToken))
+	mac.Write([]byte(b.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// signedRequest builds an inbound-style POST request and signs it the way
+// Twilio does: HMAC-SHA1(authToken, url + sorted "key"+"value" pairs).
+func signedRequest(t *testing.T, authToken, fullURL string, form url.Values) *http.Request {
+	t.Helper()
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(fullURL)
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteString(form.Get(k))
+	}
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(b.String()))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequest("POST", fullURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Twilio-Signature", sig)
+	return req
+}
+
+func TestValidSignature(t *testing.T) {
+	s := &Server{authToken: "s3cr3t"}
+	form := url.Values{"Body": {"ack-123"}, "From": {"+15550001111"}}
+	url := "https://notifier.sgs.com/twilio/inbound"
+
+	req := signedRequest(t, "s3cr3t", url, form)
+	if !s.validSignature(req, url) {
+		t.Error("validSignature rejected a correctly signed request")
+	}
+}
+
+func TestValidSignatureWrongSecret(t *testing.T) {
+	s := &Server{authToken: "s3cr3t"}
+	form := url.Values{"Body": {"ack-123"}}
+	url := "https://notifier.sgs.com/twilio/inbound"
+
+	req := signedRequest(t, "wrong-token", url, form)
+	if s.validSignature(req, url) {
+		t.Error("validSignature accepted a request signed with the wrong auth token")
+	}
+}
+
+func TestValidSignatureWrongURL(t *testing.T) {
+	s := &Server{authToken: "s3cr3t"}
+	form := url.Values{"Body": {"ack-123"}}
+
+	req := signedRequest(t, "s3cr3t", "https://notifier.sgs.com/twilio/inbound", form)
+	if s.validSignature(req, "https://notifier.sgs.com/twilio/status") {
+		t.Error("validSignature accepted a signature checked against a different route's URL")
+	}
+}
+
+func TestValidSignatureMissingHeader(t *testing.T) {
+	s := &Server{authToken: "s3cr3t"}
+	req, _ := http.NewRequest("POST", "https://notifier.sgs.com/twilio/inbound", strings.NewReader(""))
+	if s.validSignature(req, "https://notifier.sgs.com/twilio/inbound") {
+		t.Error("validSignature accepted a request with no X-Twilio-Signature header")
+	}
+}
+
+func TestValidSignatureNoAuthTokenConfigured(t *testing.T) {
+	s := &Server{}
+	form := url.Values{"Body": {"ack-123"}}
+	url := "https://notifier.sgs.com/twilio/inbound"
+	req := signedRequest(t, "", url, form)
+	if s.validSignature(req, url) {
+		t.Error("validSignature should never pass when the server has no auth token configured")
+	}
+}